@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials handles loading and describing the TLS material the operator
+// uses to serve its gRPC API.
+package credentials
+
+import "os"
+
+const (
+	rootCertFilename = "ca.crt"
+	certFilename     = "tls.crt"
+	keyFilename      = "tls.key"
+)
+
+// TLSCredentials describes where the operator's root cert, server cert and server key
+// are mounted on disk.
+type TLSCredentials struct {
+	path string
+}
+
+// NewTLSCredentials returns credentials rooted at the given path.
+func NewTLSCredentials(path string) TLSCredentials {
+	return TLSCredentials{path: path}
+}
+
+// Path returns the directory the credentials are mounted in.
+func (c TLSCredentials) Path() string {
+	return c.path
+}
+
+// RootCertPath returns the path to the root CA certificate.
+func (c TLSCredentials) RootCertPath() string {
+	return c.path + string(os.PathSeparator) + rootCertFilename
+}
+
+// CertPath returns the path to the server certificate.
+func (c TLSCredentials) CertPath() string {
+	return c.path + string(os.PathSeparator) + certFilename
+}
+
+// KeyPath returns the path to the server private key.
+func (c TLSCredentials) KeyPath() string {
+	return c.path + string(os.PathSeparator) + keyFilename
+}
+
+// CertChain holds a PEM-encoded root certificate, server certificate and server key.
+type CertChain struct {
+	RootCA []byte
+	Cert   []byte
+	Key    []byte
+}
+
+// LoadFromDisk reads and returns the root certificate, server certificate and server key
+// from the given paths.
+func LoadFromDisk(rootCertPath, certPath, keyPath string) (*CertChain, error) {
+	rootCert, err := os.ReadFile(rootCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertChain{
+		RootCA: rootCert,
+		Cert:   cert,
+		Key:    key,
+	}, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api implements the operator's gRPC API surface, consumed by Dapr sidecars
+// to watch components, configurations, subscriptions and resiliency policies.
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/kit/logger"
+)
+
+var log = logger.NewLogger("dapr.operator.api")
+
+const serverPort = 6500
+
+// CertificateProvider supplies the server certificate used to terminate the operator's
+// gRPC API and the root CA connecting sidecars are verified against. Both are consulted
+// on every new TLS handshake so that a rotated certificate or root takes effect for new
+// connections without restarting the server.
+type CertificateProvider interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	GetRootCAs() *x509.CertPool
+}
+
+// Server runs the operator's gRPC API.
+type Server interface {
+	Run(ctx context.Context, certProvider CertificateProvider, ready func()) error
+	OnComponentUpdated(component *componentsapi.Component)
+}
+
+type apiServer struct {
+	client client.Client
+
+	tracerProvider trace.TracerProvider
+	routers        []Router
+
+	// notifier fans OnComponentUpdated calls out to every sidecar currently streaming
+	// ComponentUpdate through the components router. It's a shared *ComponentNotifier
+	// rather than state private to apiServer so the router, which is constructed before
+	// the server that will hold it, can be wired to the same instance; see
+	// WithComponentNotifier and routers.NewComponentsRouter.
+	notifier *ComponentNotifier
+}
+
+// ServerOption configures optional behavior of the operator API server.
+type ServerOption func(*apiServer)
+
+// WithTracerProvider makes every RPC handled by the server produce spans through tp,
+// correlated with the spans sidecars emit for the same request.
+func WithTracerProvider(tp trace.TracerProvider) ServerOption {
+	return func(a *apiServer) {
+		a.tracerProvider = tp
+	}
+}
+
+// WithComponentNotifier makes the server publish component changes (see
+// OnComponentUpdated) through n instead of a private, otherwise-unreachable notifier.
+// Pass the same n to routers.NewComponentsRouter so its ComponentUpdate stream handler
+// actually receives what OnComponentUpdated publishes.
+func WithComponentNotifier(n *ComponentNotifier) ServerOption {
+	return func(a *apiServer) {
+		a.notifier = n
+	}
+}
+
+// NewAPIServer returns a new operator API server.
+func NewAPIServer(c client.Client, opts ...ServerOption) Server {
+	a := &apiServer{
+		client:   c,
+		notifier: NewComponentNotifier(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Run starts the gRPC server and blocks until ctx is canceled. The server certificate is
+// always resolved live through certProvider so that a rotated certificate is served to
+// new connections without needing a restart.
+func (a *apiServer) Run(ctx context.Context, certProvider CertificateProvider, ready func()) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", serverPort))
+	if err != nil {
+		return err
+	}
+
+	// GetConfigForClient is consulted on every handshake, so a rotated root CA (not just a
+	// rotated serving certificate) takes effect for new connections without a restart. It
+	// must clone the base config rather than build a fresh one: credentials.NewTLS only
+	// injects the "h2" ALPN protocol into this outer config, so a from-scratch config
+	// returned here would negotiate no protocol and fail grpc's ALPN enforcement.
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		GetCertificate: certProvider.GetCertificate,
+		NextProtos:     []string{"h2"},
+	}
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := tlsConfig.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.ClientCAs = certProvider.GetRootCAs()
+		return cfg, nil
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.ForceServerCodec(jsonCodec{}),
+		grpc.ChainUnaryInterceptor(rpcCounterInterceptor),
+	}
+	if a.tracerProvider != nil {
+		// otelgrpc's interceptor API is deprecated in favor of the stats-handler API, and
+		// the propagator must be set explicitly: the default is otel's global no-op
+		// propagator, which would make every span a new root instead of a child of the
+		// sidecar's span.
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithTracerProvider(a.tracerProvider),
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)))
+	}
+
+	s := grpc.NewServer(opts...)
+	if err := a.registerRouters(s); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Infof("Starting gRPC server on %s", lis.Addr())
+		errCh <- s.Serve(lis)
+	}()
+
+	if ready != nil {
+		ready()
+	}
+
+	select {
+	case <-ctx.Done():
+		s.GracefulStop()
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// registerRouters mounts every router added via WithRouter on s, failing if two routers
+// share a Name rather than letting the second silently shadow the first.
+func (a *apiServer) registerRouters(s *grpc.Server) error {
+	seen := make(map[string]struct{}, len(a.routers))
+	for _, r := range a.routers {
+		if _, ok := seen[r.Name()]; ok {
+			return fmt.Errorf("api: duplicate router %q", r.Name())
+		}
+		seen[r.Name()] = struct{}{}
+
+		log.Infof("Registering API router %q", r.Name())
+		r.Register(s)
+	}
+	return nil
+}
+
+func (a *apiServer) OnComponentUpdated(component *componentsapi.Component) {
+	a.notifier.Publish(component)
+}
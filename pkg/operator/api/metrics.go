@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var rpcTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "dapr",
+	Subsystem: "operator",
+	Name:      "api_rpc_total",
+	Help:      "Number of operator API RPCs served, by method and status code.",
+}, []string{"method", "code"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(rpcTotal)
+}
+
+// rpcCounterInterceptor counts every unary RPC served by the operator's gRPC API by
+// method and final status code, independent of whether OpenTelemetry tracing is enabled.
+func rpcCounterInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	rpcTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return resp, err
+}
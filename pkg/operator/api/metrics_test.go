@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestRPCCounterInterceptorCountsByMethodAndCode(t *testing.T) {
+	rpcTotal.Reset()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/dapr.proto.operator.v1.Operator/ListComponents"}
+
+	_, err := rpcCounterInterceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 1, testutil.ToFloat64(rpcTotal.WithLabelValues(info.FullMethod, "OK")), 0)
+
+	_, err = rpcCounterInterceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+	require.InDelta(t, 1, testutil.ToFloat64(rpcTotal.WithLabelValues(info.FullMethod, "Unknown")), 0)
+}
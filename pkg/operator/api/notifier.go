@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"sync"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+// ComponentNotifier fans a component change out to every sidecar currently streaming
+// ComponentUpdate. It's a standalone type, rather than a field private to apiServer, so
+// the components router (constructed before the server that will hold it) and the
+// server can share the same instance: see api.WithComponentNotifier and
+// routers.NewComponentsRouter.
+type ComponentNotifier struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]chan *componentsapi.Component
+}
+
+// NewComponentNotifier returns a notifier with no subscribers.
+func NewComponentNotifier() *ComponentNotifier {
+	return &ComponentNotifier{subs: make(map[int]chan *componentsapi.Component)}
+}
+
+// Subscribe registers a new subscriber and returns an id to later pass to Unsubscribe,
+// and the channel it will receive component updates on.
+func (n *ComponentNotifier) Subscribe() (int, <-chan *componentsapi.Component) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := n.next
+	n.next++
+	ch := make(chan *componentsapi.Component, 1)
+	n.subs[id] = ch
+	return id, ch
+}
+
+// Unsubscribe removes and closes the subscriber identified by id, e.g. once its stream's
+// context is done. It's a no-op if id was already unsubscribed.
+func (n *ComponentNotifier) Unsubscribe(id int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if ch, ok := n.subs[id]; ok {
+		delete(n.subs, id)
+		close(ch)
+	}
+}
+
+// Publish delivers c to every current subscriber, dropping it for any subscriber whose
+// buffer is already full rather than blocking the caller.
+func (n *ComponentNotifier) Publish(c *componentsapi.Component) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, ch := range n.subs {
+		select {
+		case ch <- c:
+		default:
+			log.Warn("Update channel is full; dropping component update notification")
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import "google.golang.org/grpc"
+
+// Router registers one piece of the operator's gRPC API surface against a server. Each
+// Dapr resource kind (components, configurations, subscriptions, ...) gets its own
+// Router, so a deployment can advertise a different surface per Dapr version and tests
+// can boot a server with only the routers under test.
+type Router interface {
+	// Name identifies the router, for logging and duplicate detection.
+	Name() string
+	// Register mounts the router's gRPC service(s) on s.
+	Register(s *grpc.Server)
+}
+
+// WithRouter adds r to the server's API surface. Routers are registered in the order
+// they're given to NewAPIServer; registering two routers with the same Name is an error
+// surfaced when the server starts.
+func WithRouter(r Router) ServerOption {
+	return func(a *apiServer) {
+		a.routers = append(a.routers, r)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dapr/dapr/pkg/operator/api"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+// ListComponentsRequest requests every component visible to the operator, optionally
+// scoped to a single namespace.
+type ListComponentsRequest struct {
+	Namespace string
+}
+
+// ListComponentsResponse returns the components ListComponentsRequest asked for.
+type ListComponentsResponse struct {
+	Components []componentsapi.Component
+}
+
+// ComponentUpdateRequest subscribes to component changes, optionally scoped to a single
+// namespace.
+type ComponentUpdateRequest struct {
+	Namespace string
+}
+
+// ComponentUpdateEvent is sent on the ComponentUpdate stream each time a watched
+// component changes.
+type ComponentUpdateEvent struct {
+	Component *componentsapi.Component
+}
+
+// Components_ComponentUpdateServer is the server side of the ComponentUpdate stream,
+// shaped the way protoc-gen-go-grpc would generate it for a server-streaming RPC.
+type Components_ComponentUpdateServer interface {
+	Send(*ComponentUpdateEvent) error
+	grpc.ServerStream
+}
+
+type componentsComponentUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (s *componentsComponentUpdateServer) Send(e *ComponentUpdateEvent) error {
+	return s.ServerStream.SendMsg(e)
+}
+
+// ComponentsRouter serves the components.dapr.io resource to connected sidecars.
+type ComponentsRouter struct {
+	client   client.Client
+	notifier *api.ComponentNotifier
+}
+
+// NewComponentsRouter returns a Router for components.dapr.io. notifier must be the same
+// instance passed to api.WithComponentNotifier, so that the server's OnComponentUpdated
+// reaches every sidecar currently streaming ComponentUpdate through this router.
+func NewComponentsRouter(c client.Client, notifier *api.ComponentNotifier) *ComponentsRouter {
+	return &ComponentsRouter{client: c, notifier: notifier}
+}
+
+// Name implements api.Router.
+func (r *ComponentsRouter) Name() string {
+	return "components"
+}
+
+// Register implements api.Router.
+func (r *ComponentsRouter) Register(s *grpc.Server) {
+	s.RegisterService(&componentsServiceDesc, r)
+}
+
+// ListComponents returns every component visible to the operator, filtered to
+// req.Namespace when it's non-empty.
+func (r *ComponentsRouter) ListComponents(ctx context.Context, req *ListComponentsRequest) (*ListComponentsResponse, error) {
+	var list componentsapi.ComponentList
+	var opts []client.ListOption
+	if req.Namespace != "" {
+		opts = append(opts, client.InNamespace(req.Namespace))
+	}
+	if err := r.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return &ListComponentsResponse{Components: list.Items}, nil
+}
+
+// ComponentUpdate streams a ComponentUpdateEvent to the caller every time a watched
+// component changes, until the stream's context is canceled or the caller disconnects.
+func (r *ComponentsRouter) ComponentUpdate(req *ComponentUpdateRequest, stream Components_ComponentUpdateServer) error {
+	id, updates := r.notifier.Subscribe()
+	defer r.notifier.Unsubscribe(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case component, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if req.Namespace != "" && component.Namespace != req.Namespace {
+				continue
+			}
+			if err := stream.Send(&ComponentUpdateEvent{Component: component}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+var componentsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.Components",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListComponents",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*ComponentsRouter).ListComponents)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ComponentUpdate",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				req := new(ComponentUpdateRequest)
+				if err := stream.RecvMsg(req); err != nil {
+					return err
+				}
+				return srv.(*ComponentsRouter).ComponentUpdate(req, &componentsComponentUpdateServer{stream})
+			},
+		},
+	},
+}
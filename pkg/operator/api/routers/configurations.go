@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configurationapi "github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
+)
+
+// GetConfigurationRequest identifies the configuration a sidecar is starting with.
+type GetConfigurationRequest struct {
+	Name      string
+	Namespace string
+}
+
+// GetConfigurationResponse returns the requested configuration.
+type GetConfigurationResponse struct {
+	Configuration *configurationapi.Configuration
+}
+
+// ConfigurationsRouter serves the configurations.dapr.io resource to connected sidecars.
+type ConfigurationsRouter struct {
+	client client.Client
+}
+
+// NewConfigurationsRouter returns a Router for configurations.dapr.io.
+func NewConfigurationsRouter(c client.Client) *ConfigurationsRouter {
+	return &ConfigurationsRouter{client: c}
+}
+
+// Name implements api.Router.
+func (r *ConfigurationsRouter) Name() string {
+	return "configurations"
+}
+
+// Register implements api.Router.
+func (r *ConfigurationsRouter) Register(s *grpc.Server) {
+	s.RegisterService(&configurationsServiceDesc, r)
+}
+
+// GetConfiguration returns the configuration named by req.
+func (r *ConfigurationsRouter) GetConfiguration(ctx context.Context, req *GetConfigurationRequest) (*GetConfigurationResponse, error) {
+	var config configurationapi.Configuration
+	key := client.ObjectKey{Name: req.Name, Namespace: req.Namespace}
+	if err := r.client.Get(ctx, key, &config); err != nil {
+		return nil, err
+	}
+	return &GetConfigurationResponse{Configuration: &config}, nil
+}
+
+var configurationsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.Configurations",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConfiguration",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*ConfigurationsRouter).GetConfiguration)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+// ResyncFunc is the hook ForceResync calls to make the operator re-deliver the objects
+// it watches out of band, e.g. in response to a debug request.
+type ResyncFunc func()
+
+// DumpWatchedRequest carries no fields; DumpWatched always returns every component the
+// operator currently watches.
+type DumpWatchedRequest struct{}
+
+// DumpWatchedResponse returns the components DumpWatched found.
+type DumpWatchedResponse struct {
+	Components []componentsapi.Component
+}
+
+// ForceResyncRequest carries no fields; ForceResync always calls the router's resync hook.
+type ForceResyncRequest struct{}
+
+// ForceResyncResponse carries no fields.
+type ForceResyncResponse struct{}
+
+// EvaluateTemplateRequest is a Go template and the data to render it against.
+type EvaluateTemplateRequest struct {
+	Template string
+	Data     any
+}
+
+// EvaluateTemplateResponse returns the rendered template.
+type EvaluateTemplateResponse struct {
+	Rendered string
+}
+
+// DebugRouter exposes operator introspection that isn't part of the sidecar-facing API:
+// dumping currently watched objects, forcing a resync, and evaluating a component
+// template against sample data before applying it to a cluster.
+type DebugRouter struct {
+	client client.Client
+	resync ResyncFunc
+}
+
+// NewDebugRouter returns a Router exposing operator debug operations. resync is called
+// whenever a client requests a forced resync; it may be nil if the caller doesn't wire
+// one up, in which case ForceResync is a no-op.
+func NewDebugRouter(c client.Client, resync ResyncFunc) *DebugRouter {
+	return &DebugRouter{client: c, resync: resync}
+}
+
+// Name implements api.Router.
+func (r *DebugRouter) Name() string {
+	return "debug"
+}
+
+// Register implements api.Router.
+func (r *DebugRouter) Register(s *grpc.Server) {
+	s.RegisterService(&debugServiceDesc, r)
+}
+
+// rpcDumpWatched adapts DumpWatched to the Req/Resp shape newUnaryHandler expects.
+func (r *DebugRouter) rpcDumpWatched(ctx context.Context, _ *DumpWatchedRequest) (*DumpWatchedResponse, error) {
+	components, err := r.DumpWatched(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DumpWatchedResponse{Components: components}, nil
+}
+
+// rpcForceResync adapts ForceResync to the Req/Resp shape newUnaryHandler expects.
+func (r *DebugRouter) rpcForceResync(_ context.Context, _ *ForceResyncRequest) (*ForceResyncResponse, error) {
+	r.ForceResync()
+	return &ForceResyncResponse{}, nil
+}
+
+// rpcEvaluateTemplate adapts EvaluateTemplate to the Req/Resp shape newUnaryHandler
+// expects.
+func (r *DebugRouter) rpcEvaluateTemplate(_ context.Context, req *EvaluateTemplateRequest) (*EvaluateTemplateResponse, error) {
+	rendered, err := r.EvaluateTemplate(req.Template, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &EvaluateTemplateResponse{Rendered: rendered}, nil
+}
+
+var debugServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.Debug",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DumpWatched",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*DebugRouter).rpcDumpWatched)(srv, ctx, dec, interceptor)
+			},
+		},
+		{
+			MethodName: "ForceResync",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*DebugRouter).rpcForceResync)(srv, ctx, dec, interceptor)
+			},
+		},
+		{
+			MethodName: "EvaluateTemplate",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*DebugRouter).rpcEvaluateTemplate)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+}
+
+// DumpWatched lists every component the operator currently watches, across all
+// namespaces it has access to.
+func (r *DebugRouter) DumpWatched(ctx context.Context) ([]componentsapi.Component, error) {
+	var list componentsapi.ComponentList
+	if err := r.client.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ForceResync calls the resync hook passed to NewDebugRouter; it's a no-op if that was nil.
+func (r *DebugRouter) ForceResync() {
+	if r.resync != nil {
+		r.resync()
+	}
+}
+
+// EvaluateTemplate renders tpl as a Go template against data, so an operator can check
+// how a component manifest will render before applying it to a cluster.
+func (r *DebugRouter) EvaluateTemplate(tpl string, data any) (string, error) {
+	t, err := template.New("component").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+func TestDebugRouterDumpWatched(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, componentsapi.AddToScheme(scheme))
+
+	comp := &componentsapi.Component{ObjectMeta: metav1.ObjectMeta{Name: "statestore", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(comp).Build()
+
+	r := NewDebugRouter(c, nil)
+	watched, err := r.DumpWatched(context.Background())
+	require.NoError(t, err)
+	require.Len(t, watched, 1)
+	require.Equal(t, "statestore", watched[0].Name)
+}
+
+func TestDebugRouterForceResyncCallsHook(t *testing.T) {
+	called := false
+	r := NewDebugRouter(nil, func() { called = true })
+	r.ForceResync()
+	require.True(t, called)
+}
+
+func TestDebugRouterEvaluateTemplate(t *testing.T) {
+	r := NewDebugRouter(nil, nil)
+	out, err := r.EvaluateTemplate("hello {{ .Name }}", struct{ Name string }{Name: "world"})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", out)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	resiliencyapi "github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
+)
+
+// ListResiliencyRequest requests every resiliency policy visible to the operator,
+// optionally scoped to a single namespace.
+type ListResiliencyRequest struct {
+	Namespace string
+}
+
+// ListResiliencyResponse returns the resiliency policies ListResiliencyRequest asked for.
+type ListResiliencyResponse struct {
+	Resiliencies []resiliencyapi.Resiliency
+}
+
+// ResiliencyRouter serves the resiliencies.dapr.io resource to connected sidecars.
+type ResiliencyRouter struct {
+	client client.Client
+}
+
+// NewResiliencyRouter returns a Router for resiliencies.dapr.io.
+func NewResiliencyRouter(c client.Client) *ResiliencyRouter {
+	return &ResiliencyRouter{client: c}
+}
+
+// Name implements api.Router.
+func (r *ResiliencyRouter) Name() string {
+	return "resiliency"
+}
+
+// Register implements api.Router.
+func (r *ResiliencyRouter) Register(s *grpc.Server) {
+	s.RegisterService(&resiliencyServiceDesc, r)
+}
+
+// ListResiliency returns every resiliency policy visible to the operator, filtered to
+// req.Namespace when it's non-empty.
+func (r *ResiliencyRouter) ListResiliency(ctx context.Context, req *ListResiliencyRequest) (*ListResiliencyResponse, error) {
+	var list resiliencyapi.ResiliencyList
+	var opts []client.ListOption
+	if req.Namespace != "" {
+		opts = append(opts, client.InNamespace(req.Namespace))
+	}
+	if err := r.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return &ListResiliencyResponse{Resiliencies: list.Items}, nil
+}
+
+var resiliencyServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.Resiliency",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListResiliency",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*ResiliencyRouter).ListResiliency)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routers provides the built-in api.Router implementations the operator ships:
+// one per Dapr resource kind it serves to sidecars, plus a debug router for operators.
+// Each is independent of the others, so a deployment can mix and match which ones it
+// advertises via api.WithRouter.
+package routers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/dapr/kit/logger"
+)
+
+var log = logger.NewLogger("dapr.operator.api.routers")
+
+// newUnaryHandler adapts a router's bound method into a grpc.MethodDesc.Handler, the
+// shape protoc-gen-go-grpc would generate for a unary RPC, without requiring one of
+// these resources to have an actual .proto-generated service stub.
+func newUnaryHandler[Req any, Resp any](method func(context.Context, *Req) (*Resp, error)) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return method(ctx, req)
+		}
+		fullMethod, _ := grpc.Method(ctx)
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return method(ctx, req.(*Req))
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	subscriptionsapiV1alpha1 "github.com/dapr/dapr/pkg/apis/subscriptions/v1alpha1"
+	subscriptionsapiV2alpha1 "github.com/dapr/dapr/pkg/apis/subscriptions/v2alpha1"
+)
+
+// ListSubscriptionsRequest requests every subscription visible to the operator,
+// optionally scoped to a single namespace.
+type ListSubscriptionsRequest struct {
+	Namespace string
+}
+
+// ListSubscriptionsV1Alpha1Response returns the subscriptions.dapr.io/v1alpha1 resources
+// ListSubscriptionsRequest asked for.
+type ListSubscriptionsV1Alpha1Response struct {
+	Subscriptions []subscriptionsapiV1alpha1.Subscription
+}
+
+// ListSubscriptionsV2Alpha1Response returns the subscriptions.dapr.io/v2alpha1 resources
+// ListSubscriptionsRequest asked for.
+type ListSubscriptionsV2Alpha1Response struct {
+	Subscriptions []subscriptionsapiV2alpha1.Subscription
+}
+
+// SubscriptionsV1Alpha1Router serves subscriptions.dapr.io/v1alpha1 to connected
+// sidecars that haven't upgraded to the v2alpha1 shape yet.
+type SubscriptionsV1Alpha1Router struct {
+	client client.Client
+}
+
+// NewSubscriptionsV1Alpha1Router returns a Router for subscriptions.dapr.io/v1alpha1.
+func NewSubscriptionsV1Alpha1Router(c client.Client) *SubscriptionsV1Alpha1Router {
+	return &SubscriptionsV1Alpha1Router{client: c}
+}
+
+// Name implements api.Router.
+func (r *SubscriptionsV1Alpha1Router) Name() string {
+	return "subscriptions.v1alpha1"
+}
+
+// Register implements api.Router.
+func (r *SubscriptionsV1Alpha1Router) Register(s *grpc.Server) {
+	s.RegisterService(&subscriptionsV1Alpha1ServiceDesc, r)
+}
+
+// ListSubscriptions returns every subscriptions.dapr.io/v1alpha1 resource visible to the
+// operator, filtered to req.Namespace when it's non-empty.
+func (r *SubscriptionsV1Alpha1Router) ListSubscriptions(ctx context.Context, req *ListSubscriptionsRequest) (*ListSubscriptionsV1Alpha1Response, error) {
+	var list subscriptionsapiV1alpha1.SubscriptionList
+	var opts []client.ListOption
+	if req.Namespace != "" {
+		opts = append(opts, client.InNamespace(req.Namespace))
+	}
+	if err := r.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return &ListSubscriptionsV1Alpha1Response{Subscriptions: list.Items}, nil
+}
+
+var subscriptionsV1Alpha1ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.SubscriptionsV1Alpha1",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSubscriptions",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*SubscriptionsV1Alpha1Router).ListSubscriptions)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+}
+
+// SubscriptionsV2Alpha1Router serves subscriptions.dapr.io/v2alpha1 to connected
+// sidecars, independently of the v1alpha1 router so a deployment can run either, both,
+// or neither depending on what's rolled out.
+type SubscriptionsV2Alpha1Router struct {
+	client client.Client
+}
+
+// NewSubscriptionsV2Alpha1Router returns a Router for subscriptions.dapr.io/v2alpha1.
+func NewSubscriptionsV2Alpha1Router(c client.Client) *SubscriptionsV2Alpha1Router {
+	return &SubscriptionsV2Alpha1Router{client: c}
+}
+
+// Name implements api.Router.
+func (r *SubscriptionsV2Alpha1Router) Name() string {
+	return "subscriptions.v2alpha1"
+}
+
+// Register implements api.Router.
+func (r *SubscriptionsV2Alpha1Router) Register(s *grpc.Server) {
+	s.RegisterService(&subscriptionsV2Alpha1ServiceDesc, r)
+}
+
+// ListSubscriptions returns every subscriptions.dapr.io/v2alpha1 resource visible to the
+// operator, filtered to req.Namespace when it's non-empty.
+func (r *SubscriptionsV2Alpha1Router) ListSubscriptions(ctx context.Context, req *ListSubscriptionsRequest) (*ListSubscriptionsV2Alpha1Response, error) {
+	var list subscriptionsapiV2alpha1.SubscriptionList
+	var opts []client.ListOption
+	if req.Namespace != "" {
+		opts = append(opts, client.InNamespace(req.Namespace))
+	}
+	if err := r.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return &ListSubscriptionsV2Alpha1Response{Subscriptions: list.Items}, nil
+}
+
+var subscriptionsV2Alpha1ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.operator.v1.SubscriptionsV2Alpha1",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSubscriptions",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				return newUnaryHandler(srv.(*SubscriptionsV2Alpha1Router).ListSubscriptions)(srv, ctx, dec, interceptor)
+			},
+		},
+	},
+}
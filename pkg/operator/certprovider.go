@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/dapr/pkg/credentials"
+	"github.com/dapr/kit/fswatcher"
+)
+
+// CertProvider owns the operator's serving certificate and keeps it in sync with the
+// cert/key/root files on disk, so that rotations performed out-of-band (cert-manager,
+// the Dapr sentry, or an operator redeploying secrets) are picked up without restarting
+// the process. Callers read the current certificate through GetCertificate, safe to wire
+// directly into a tls.Config, and verify connecting sidecars against GetRootCAs.
+type CertProvider struct {
+	rootCertPath string
+	certPath     string
+	keyPath      string
+
+	current   atomic.Pointer[tls.Certificate]
+	currentCA atomic.Pointer[x509.CertPool]
+	reload    chan struct{}
+}
+
+// NewCertProvider loads the initial certificate chain from disk and returns a
+// CertProvider ready to be started with Run.
+func NewCertProvider(rootCertPath, certPath, keyPath string) (*CertProvider, error) {
+	cp := &CertProvider{
+		rootCertPath: rootCertPath,
+		certPath:     certPath,
+		keyPath:      keyPath,
+		reload:       make(chan struct{}, 1),
+	}
+
+	if err := cp.reloadCert(); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// Run starts the filesystem watcher that reloads the certificate whenever any of
+// RootCertPath, CertPath or KeyPath change on disk. It blocks until ctx is canceled or
+// the filesystem watcher stops, and returns nil in both cases.
+func (c *CertProvider) Run(ctx context.Context) error {
+	fsevent := make(chan struct{})
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+
+	// Watch the mount directory rather than c.certPath itself: Kubernetes secret and
+	// cert-manager rotations swap the volume's "..data" symlink atomically, which doesn't
+	// generate fsnotify events on the leaf file path a watch was opened against.
+	watchDir := filepath.Dir(c.certPath)
+	go func() {
+		log.Infof("Starting TLS certificate watch on: %s", watchDir)
+		err := fswatcher.Watch(watchCtx, watchDir, fsevent)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Errorf("Error watching TLS certificate files: %s", err)
+		}
+		close(fsevent)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.reload:
+			c.tryReload()
+		case _, ok := <-fsevent:
+			if !ok {
+				return nil
+			}
+			c.tryReload()
+		}
+	}
+}
+
+// Sighup forces an immediate reload of the certificate from disk, for use by a
+// process-level SIGHUP handler when a filesystem event isn't reliable (e.g. some
+// mounted secret volumes don't emit fsnotify events on update).
+func (c *CertProvider) Sighup() {
+	select {
+	case c.reload <- struct{}{}:
+	default:
+	}
+}
+
+func (c *CertProvider) tryReload() {
+	if err := c.reloadCert(); err != nil {
+		log.Errorf("Failed to reload TLS certificate, keeping previous certificate in use: %s", err)
+		return
+	}
+	log.Info("TLS certificate reloaded successfully")
+}
+
+func (c *CertProvider) reloadCert() error {
+	chain, err := credentials.LoadFromDisk(c.rootCertPath, c.certPath, c.keyPath)
+	if err != nil {
+		return err
+	}
+
+	cert, roots, err := validateCertChain(chain)
+	if err != nil {
+		return err
+	}
+
+	c.current.Store(cert)
+	c.currentCA.Store(roots)
+	recordCertReload()
+	return nil
+}
+
+// validateCertChain parses the PEM-encoded cert/key pair, checks that the leaf
+// certificate is within its not-before/not-after validity window, confirms the
+// certificate matches the private key it's paired with, and parses the root CA into a
+// pool callers can verify client certificates against.
+func validateCertChain(chain *credentials.CertChain) (*tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.X509KeyPair(chain.Cert, chain.Key)
+	if err != nil {
+		return nil, nil, errors.New("cert does not match private key: " + err.Error())
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, nil, errors.New("failed to parse leaf certificate: " + err.Error())
+	}
+
+	now := time.Now()
+	if now.Before(leaf.NotBefore) {
+		return nil, nil, errors.New("certificate is not yet valid")
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, nil, errors.New("certificate has expired")
+	}
+
+	cert.Leaf = leaf
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(chain.RootCA) {
+		return nil, nil, errors.New("failed to parse root certificate")
+	}
+
+	return &cert, roots, nil
+}
+
+// GetCertificate implements the signature expected by tls.Config.GetCertificate, always
+// returning the most recently loaded server certificate.
+func (c *CertProvider) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.current.Load(), nil
+}
+
+// GetRootCAs returns the most recently loaded root CA pool, for verifying the client
+// certificates presented by connecting Dapr sidecars.
+func (c *CertProvider) GetRootCAs() *x509.CertPool {
+	return c.currentCA.Load()
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CRDRequirement identifies a CustomResourceDefinition the operator depends on, and the
+// versions of it that must be served before the operator can rely on it.
+type CRDRequirement struct {
+	// Name is the CRD's full name, e.g. "components.dapr.io".
+	Name string
+	// Versions are the served versions that must be present, e.g. ["v1alpha1"].
+	Versions []string
+}
+
+// defaultRequiredCRDs are the CRDs the operator cannot function without.
+var defaultRequiredCRDs = []CRDRequirement{
+	{Name: "components.dapr.io", Versions: []string{"v1alpha1"}},
+	{Name: "configurations.dapr.io", Versions: []string{"v1alpha1"}},
+}
+
+// defaultOptionalCRDs are CRDs the operator supports but can run without, degrading
+// gracefully (skipping the informer for that resource) if they're missing.
+var defaultOptionalCRDs = []CRDRequirement{
+	{Name: "resiliencies.dapr.io", Versions: []string{"v1alpha1"}},
+	{Name: "subscriptions.dapr.io", Versions: []string{"v1alpha1", "v2alpha1"}},
+}
+
+// crdWaiter is a controller-runtime reconciler that watches CustomResourceDefinition
+// objects and reports, via Ready, once every required CRD is installed and Established.
+// Optional CRDs that never show up are logged and skipped rather than blocking startup.
+type crdWaiter struct {
+	client client.Client
+
+	required []CRDRequirement
+	optional []CRDRequirement
+
+	once  sync.Once
+	ready chan struct{}
+
+	mu                  sync.Mutex
+	establishedOptional map[string]bool
+}
+
+// newCRDWaiter returns a crdWaiter for the given required/optional CRDs.
+func newCRDWaiter(c client.Client, required, optional []CRDRequirement) *crdWaiter {
+	return &crdWaiter{
+		client:              c,
+		required:            required,
+		optional:            optional,
+		ready:                make(chan struct{}),
+		establishedOptional: make(map[string]bool, len(optional)),
+	}
+}
+
+// Ready returns a channel that's closed once all required CRDs are installed and
+// Established.
+func (w *crdWaiter) Ready() <-chan struct{} {
+	return w.ready
+}
+
+// SetupWithManager registers the waiter against the manager's CustomResourceDefinition
+// watch.
+func (w *crdWaiter) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiextensionsv1.CustomResourceDefinition{}).
+		Complete(w)
+}
+
+// Reconcile implements reconcile.Reconciler. It re-evaluates the full required/optional
+// set on every event rather than tracking the single changed object, since CRD
+// installation order isn't guaranteed and this keeps the waiter simple and correct.
+func (w *crdWaiter) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	for _, req := range w.required {
+		established, err := w.isEstablished(ctx, req)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !established {
+			return reconcile.Result{}, nil
+		}
+	}
+
+	for _, opt := range w.optional {
+		established, err := w.isEstablished(ctx, opt)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		w.mu.Lock()
+		wasEstablished, seen := w.establishedOptional[opt.Name]
+		w.establishedOptional[opt.Name] = established
+		w.mu.Unlock()
+
+		// Only log on a genuine transition (or the first time this CRD is observed), so
+		// the CRD watch reconciling on every CRD in the cluster doesn't spam the log with
+		// the same message on every pass.
+		switch {
+		case established && (!seen || !wasEstablished):
+			log.Infof("Optional CRD %s is now installed and established", opt.Name)
+		case !established && (!seen || wasEstablished):
+			log.Warnf("Optional CRD %s is not installed; the corresponding informer will be skipped", opt.Name)
+		}
+	}
+
+	w.once.Do(func() {
+		log.Info("All required CRDs are installed and established")
+		close(w.ready)
+	})
+
+	return reconcile.Result{}, nil
+}
+
+// isOptionalEstablished reports whether the optional CRD identified by name was last
+// observed as Established, so callers can decide whether to start its informer.
+func (w *crdWaiter) isOptionalEstablished(name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.establishedOptional[name]
+}
+
+func (w *crdWaiter) isEstablished(ctx context.Context, req CRDRequirement) (bool, error) {
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := w.client.Get(ctx, types.NamespacedName{Name: req.Name}, &crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	established := false
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established && cond.Status == apiextensionsv1.ConditionTrue {
+			established = true
+			break
+		}
+	}
+	if !established {
+		return false, nil
+	}
+
+	served := map[string]bool{}
+	for _, v := range crd.Spec.Versions {
+		served[v.Name] = v.Served
+	}
+	for _, version := range req.Versions {
+		if !served[version] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
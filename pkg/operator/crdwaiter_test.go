@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func establishedCRD(name string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	specVersions := make([]apiextensionsv1.CustomResourceDefinitionVersion, 0, len(versions))
+	for _, v := range versions {
+		specVersions = append(specVersions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Versions: specVersions},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func newTestCRDWaiter(t *testing.T, required, optional []CRDRequirement, objs ...runtime.Object) *crdWaiter {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+	return newCRDWaiter(c, required, optional)
+}
+
+func TestCRDWaiterReadyWhenRequiredCRDsEstablished(t *testing.T) {
+	required := []CRDRequirement{{Name: "components.dapr.io", Versions: []string{"v1alpha1"}}}
+	w := newTestCRDWaiter(t, required, nil, establishedCRD("components.dapr.io", "v1alpha1"))
+
+	_, err := w.Reconcile(context.Background(), reconcile.Request{})
+	require.NoError(t, err)
+
+	select {
+	case <-w.Ready():
+	default:
+		t.Fatal("expected waiter to be ready once all required CRDs are established")
+	}
+}
+
+func TestCRDWaiterBlocksOnMissingRequiredCRD(t *testing.T) {
+	required := []CRDRequirement{{Name: "components.dapr.io", Versions: []string{"v1alpha1"}}}
+	w := newTestCRDWaiter(t, required, nil)
+
+	_, err := w.Reconcile(context.Background(), reconcile.Request{})
+	require.NoError(t, err)
+
+	select {
+	case <-w.Ready():
+		t.Fatal("did not expect waiter to be ready without the required CRD")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCRDWaiterDegradesGracefullyForMissingOptionalCRD(t *testing.T) {
+	required := []CRDRequirement{{Name: "components.dapr.io", Versions: []string{"v1alpha1"}}}
+	optional := []CRDRequirement{{Name: "subscriptions.dapr.io", Versions: []string{"v1alpha1", "v2alpha1"}}}
+	w := newTestCRDWaiter(t, required, optional, establishedCRD("components.dapr.io", "v1alpha1"))
+
+	_, err := w.Reconcile(context.Background(), reconcile.Request{})
+	require.NoError(t, err)
+
+	select {
+	case <-w.Ready():
+	default:
+		t.Fatal("missing optional CRD should not block readiness")
+	}
+	require.False(t, w.isOptionalEstablished("subscriptions.dapr.io"))
+}
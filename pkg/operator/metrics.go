@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	componentsWatched = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dapr",
+		Subsystem: "operator",
+		Name:      "components_watched",
+		Help:      "Number of components currently watched by the operator, by namespace.",
+	}, []string{"namespace"})
+
+	syncComponentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dapr",
+		Subsystem: "operator",
+		Name:      "sync_component_total",
+		Help:      "Number of syncComponent invocations, by namespace.",
+	}, []string{"namespace"})
+
+	certReloadTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dapr",
+		Subsystem: "operator",
+		Name:      "cert_reload_total",
+		Help:      "Number of times the operator's TLS certificate has been reloaded from disk.",
+	})
+
+	certLastReloadTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dapr",
+		Subsystem: "operator",
+		Name:      "cert_last_reload_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful TLS certificate reload.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(componentsWatched, syncComponentTotal, certReloadTotal, certLastReloadTimestamp)
+}
+
+// recordCertReload updates the cert reload collectors; called by CertProvider every time
+// it successfully loads a certificate from disk.
+func recordCertReload() {
+	certReloadTotal.Inc()
+	certLastReloadTimestamp.Set(float64(time.Now().Unix()))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OperatorModule is an optional subsystem of the operator, such as the Dapr Watchdog or
+// the gRPC API server. Modules are wired up by name rather than call order, so forks and
+// tests can swap or omit subsystems without touching NewOperator.
+type OperatorModule struct {
+	// Name identifies the module and is referenced by other modules' Deps.
+	Name string
+	// Deps are the names of modules that must have their Init run first. Listing a dep
+	// that isn't in the module set passed to NewOperator is a wiring error.
+	Deps []string
+	// OptionalDeps are the names of modules that must run first if they're present in
+	// the module set, but whose absence is not an error. Use this for a module that
+	// only enhances another (e.g. tracing enriching the API server) rather than being
+	// required by it, so ordering is still enforced whenever both are wired in but
+	// either can be omitted on its own.
+	OptionalDeps []string
+	// Init wires the module into the operator. It's run under the manager's context,
+	// after all of its Deps have been initialized.
+	Init func(ctx context.Context, o *operator) error
+}
+
+// sortModules topologically sorts modules by Deps and OptionalDeps, so every module runs
+// after the modules it depends on. It fails fast on an unknown required dependency or a
+// cycle rather than silently skipping the offending module, since either indicates a
+// wiring mistake; an OptionalDep that isn't present in modules is simply ignored.
+func sortModules(modules []OperatorModule) ([]OperatorModule, error) {
+	byName := make(map[string]OperatorModule, len(modules))
+	for _, m := range modules {
+		if _, ok := byName[m.Name]; ok {
+			return nil, fmt.Errorf("operator: duplicate module %q", m.Name)
+		}
+		byName[m.Name] = m
+	}
+	for _, m := range modules {
+		for _, dep := range m.Deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("operator: module %q depends on unknown module %q", m.Name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(modules))
+	sorted := make([]OperatorModule, 0, len(modules))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("operator: module dependency cycle: %s -> %s", strings.Join(path, " -> "), name)
+		}
+
+		state[name] = visiting
+		m := byName[name]
+		for _, dep := range m.Deps {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		for _, dep := range m.OptionalDeps {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		sorted = append(sorted, m)
+		return nil
+	}
+
+	for _, m := range modules {
+		if err := visit(m.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortModulesOrdersByDependency(t *testing.T) {
+	modules := []OperatorModule{
+		{Name: "c", Deps: []string{"b"}},
+		{Name: "b", Deps: []string{"a"}},
+		{Name: "a"},
+	}
+
+	sorted, err := sortModules(modules)
+	require.NoError(t, err)
+
+	names := make([]string, len(sorted))
+	for i, m := range sorted {
+		names[i] = m.Name
+	}
+	require.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestSortModulesFailsOnMissingDependency(t *testing.T) {
+	_, err := sortModules([]OperatorModule{{Name: "a", Deps: []string{"missing"}}})
+	require.Error(t, err)
+}
+
+func TestSortModulesFailsOnCycle(t *testing.T) {
+	modules := []OperatorModule{
+		{Name: "a", Deps: []string{"b"}},
+		{Name: "b", Deps: []string{"a"}},
+	}
+
+	_, err := sortModules(modules)
+	require.Error(t, err)
+}
+
+func TestSortModulesFailsOnDuplicateName(t *testing.T) {
+	modules := []OperatorModule{
+		{Name: "a"},
+		{Name: "a"},
+	}
+
+	_, err := sortModules(modules)
+	require.Error(t, err)
+}
+
+func TestSortModulesOrdersByOptionalDependencyWhenPresent(t *testing.T) {
+	modules := []OperatorModule{
+		{Name: "b", OptionalDeps: []string{"a"}},
+		{Name: "a"},
+	}
+
+	sorted, err := sortModules(modules)
+	require.NoError(t, err)
+
+	names := make([]string, len(sorted))
+	for i, m := range sorted {
+		names[i] = m.Name
+	}
+	require.Equal(t, []string{"a", "b"}, names)
+}
+
+func TestSortModulesIgnoresMissingOptionalDependency(t *testing.T) {
+	_, err := sortModules([]OperatorModule{{Name: "a", OptionalDeps: []string{"missing"}}})
+	require.NoError(t, err)
+}
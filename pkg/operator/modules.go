@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/dapr/dapr/pkg/operator/api"
+	"github.com/dapr/dapr/pkg/operator/api/routers"
+	"github.com/dapr/dapr/pkg/operator/handlers"
+)
+
+// Built-in module names, exported so downstream forks can reference them in Deps when
+// composing a custom module list.
+const (
+	ModuleWatchdog          = "watchdog"
+	ModuleArgoRollouts      = "argo-rollouts"
+	ModuleServiceReconciler = "service-reconciler"
+	ModuleComponentInformer = "component-informer"
+	ModuleCertProvider      = "cert-provider"
+	ModuleAPIServer         = "api-server"
+	ModuleHealthz           = "healthz"
+	ModuleMetrics           = "metrics"
+	ModuleTracing           = "tracing"
+)
+
+// WatchdogModule runs the Dapr Watchdog, which restarts sidecar-less Dapr app pods.
+// Leader election is required; if it isn't enabled on the manager, it's still safe to
+// run but every replica will watchdog independently.
+func WatchdogModule(interval time.Duration, maxRestartsPerMin int, leaderElection bool) OperatorModule {
+	return OperatorModule{
+		Name: ModuleWatchdog,
+		Init: func(_ context.Context, o *operator) error {
+			if !leaderElection {
+				log.Warn("Leadership election is forcibly enabled when the Dapr Watchdog is enabled")
+			}
+			wd := &DaprWatchdog{
+				client:            o.client,
+				interval:          interval,
+				maxRestartsPerMin: maxRestartsPerMin,
+			}
+			return o.mgr.Add(wd)
+		},
+	}
+}
+
+// ArgoRolloutsModule enables Argo Rollouts support in the service reconciler. It is a
+// declared dependency of ServiceReconcilerModule, which reads the flag it sets when it
+// initializes, so the topological sort runs it first regardless of list order.
+func ArgoRolloutsModule() OperatorModule {
+	return OperatorModule{
+		Name: ModuleArgoRollouts,
+		Init: func(_ context.Context, o *operator) error {
+			o.argoRolloutsEnabled = true
+			return nil
+		},
+	}
+}
+
+// ServiceReconcilerModule reconciles Kubernetes Services for Dapr-enabled apps. It
+// declares ArgoRolloutsModule as an optional dependency: if the module list also
+// includes ArgoRolloutsModule, it's guaranteed to initialize first regardless of list
+// order, so o.argoRolloutsEnabled is populated before this module reads it; if Argo
+// support isn't wired in at all, this module runs with it left false.
+func ServiceReconcilerModule() OperatorModule {
+	return OperatorModule{
+		Name:         ModuleServiceReconciler,
+		OptionalDeps: []string{ModuleArgoRollouts},
+		Init: func(_ context.Context, o *operator) error {
+			daprHandler := handlers.NewDaprHandlerWithOptions(o.mgr, &handlers.Options{
+				ArgoRolloutServiceReconcilerEnabled: o.argoRolloutsEnabled,
+			})
+			return daprHandler.Init()
+		},
+	}
+}
+
+// ComponentInformerModule waits for the required CRDs to be installed, then wires up the
+// components.dapr.io informer. Optional CRDs (resiliency, subscriptions) that aren't
+// installed are skipped with a warning rather than blocking startup.
+func ComponentInformerModule(required, optional []CRDRequirement) OperatorModule {
+	return OperatorModule{
+		Name: ModuleComponentInformer,
+		Init: func(_ context.Context, o *operator) error {
+			if required == nil {
+				required = defaultRequiredCRDs
+			}
+			if optional == nil {
+				optional = defaultOptionalCRDs
+			}
+			o.crdWaiter = newCRDWaiter(o.client, required, optional)
+			return o.crdWaiter.SetupWithManager(o.mgr)
+		},
+	}
+}
+
+// CertProviderModule loads the operator's serving certificate from path and keeps it
+// fresh via hot reload for the lifetime of the process. See CertProvider.
+func CertProviderModule(path string) OperatorModule {
+	return OperatorModule{
+		Name: ModuleCertProvider,
+		Init: func(_ context.Context, o *operator) error {
+			o.certChainPath = path
+			o.certProviderEnabled = true
+			return nil
+		},
+	}
+}
+
+// TracingModule initializes an OTLP/gRPC exporter and TracerProvider and makes it
+// available to APIServerModule, so RPCs handled by the operator's API produce spans
+// correlated with the sidecar's traces. APIServerModule declares it as an optional
+// dependency, so it initializes first whenever both are in the module list, whatever
+// their order.
+func TracingModule(endpoint string, sampler sdktrace.Sampler) OperatorModule {
+	return OperatorModule{
+		Name: ModuleTracing,
+		Init: func(ctx context.Context, o *operator) error {
+			tp, err := newTracerProvider(ctx, endpoint, sampler)
+			if err != nil {
+				return err
+			}
+			o.tracerProvider = tp
+
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := tp.Shutdown(shutdownCtx); err != nil {
+					log.Errorf("Error shutting down tracer provider: %s", err)
+				}
+			}()
+
+			return nil
+		},
+	}
+}
+
+// APIServerModule starts the operator's gRPC API, serving components, configurations and
+// related resources to connected Dapr sidecars. It depends on the cert provider for its
+// TLS material and on the component informer for the data it serves. TracingModule is an
+// optional dependency: if it's also in the module list it's guaranteed to run first so
+// o.tracerProvider is set before this module reads it, but APIServerModule works fine
+// without tracing wired in at all.
+//
+// The API surface is built from independent routers (see package
+// github.com/dapr/dapr/pkg/operator/api/routers), so a fork or test can call
+// api.NewAPIServer directly with a different set of api.WithRouter options instead of
+// using this module, to advertise a narrower or custom surface.
+func APIServerModule() OperatorModule {
+	return OperatorModule{
+		Name:         ModuleAPIServer,
+		Deps:         []string{ModuleCertProvider, ModuleComponentInformer},
+		OptionalDeps: []string{ModuleTracing},
+		Init: func(_ context.Context, o *operator) error {
+			notifier := api.NewComponentNotifier()
+			opts := []api.ServerOption{
+				api.WithComponentNotifier(notifier),
+				api.WithRouter(routers.NewComponentsRouter(o.client, notifier)),
+				api.WithRouter(routers.NewConfigurationsRouter(o.client)),
+				api.WithRouter(routers.NewSubscriptionsV1Alpha1Router(o.client)),
+				api.WithRouter(routers.NewSubscriptionsV2Alpha1Router(o.client)),
+				api.WithRouter(routers.NewResiliencyRouter(o.client)),
+				api.WithRouter(routers.NewDebugRouter(o.client, o.forceResync)),
+			}
+			if o.tracerProvider != nil {
+				opts = append(opts, api.WithTracerProvider(o.tracerProvider))
+			}
+			o.apiServer = api.NewAPIServer(o.client, opts...)
+			return nil
+		},
+	}
+}
+
+// HealthzModule starts the operator's healthz server on port.
+func HealthzModule(port int) OperatorModule {
+	return OperatorModule{
+		Name: ModuleHealthz,
+		Init: func(_ context.Context, o *operator) error {
+			o.healthzPort = port
+			return nil
+		},
+	}
+}
+
+// MetricsModule enables the manager's built-in Prometheus metrics (reconcile counts and
+// latencies, workqueue depth, leader-election state) alongside the operator's own
+// collectors (components watched, syncComponent invocations, cert reloads, API RPCs). The
+// bind address is Options.MetricsBindAddress, read by NewOperator before the manager is
+// built; this module only needs to exist in the list for that address to take effect.
+func MetricsModule() OperatorModule {
+	return OperatorModule{
+		Name: ModuleMetrics,
+		Init: func(_ context.Context, o *operator) error {
+			log.Info("Prometheus metrics enabled")
+			return nil
+		},
+	}
+}
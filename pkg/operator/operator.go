@@ -16,8 +16,13 @@ package operator
 import (
 	"context"
 	"errors"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -33,41 +38,45 @@ import (
 	"github.com/dapr/dapr/pkg/credentials"
 	"github.com/dapr/dapr/pkg/health"
 	"github.com/dapr/dapr/pkg/operator/api"
-	"github.com/dapr/dapr/pkg/operator/handlers"
 	"github.com/dapr/kit/fswatcher"
 	"github.com/dapr/kit/logger"
 )
 
 var log = logger.NewLogger("dapr.operator")
 
-const (
-	healthzPort = 8080
-)
-
 // Operator is an Dapr Kubernetes Operator for managing components and sidecar lifecycle.
 type Operator interface {
 	Run(ctx context.Context)
 }
 
-// Options contains the options for `NewOperator`.
+// Options contains the core options for `NewOperator`. Feature subsystems (the
+// watchdog, the service reconciler, the API server, ...) are no longer flags here; they
+// are enabled by passing the corresponding OperatorModule to NewOperator.
 type Options struct {
-	Config                              string
-	CertChainPath                       string
-	LeaderElection                      bool
-	WatchdogEnabled                     bool
-	WatchdogInterval                    time.Duration
-	WatchdogMaxRestartsPerMin           int
-	WatchNamespace                      string
-	ServiceReconcilerEnabled            bool
-	ArgoRolloutServiceReconcilerEnabled bool
+	Config         string
+	LeaderElection bool
+	WatchNamespace string
+
+	// MetricsBindAddress is the address controller-runtime's built-in metrics (reconcile
+	// counts/latencies, workqueue depth, leader-election state) are served on, alongside
+	// the operator's own collectors. Only takes effect if MetricsModule is included in
+	// the module list; defaults to ":9090" when unset.
+	MetricsBindAddress string
 }
 
 type operator struct {
 	apiServer api.Server
 
-	configName    string
-	certChainPath string
-	config        *Config
+	configName          string
+	certChainPath       string
+	certProviderEnabled bool
+	healthzPort         int
+	argoRolloutsEnabled bool
+	tracerProvider      trace.TracerProvider
+
+	config       *Config
+	certProvider *CertProvider
+	crdWaiter    *crdWaiter
 
 	mgr    ctrl.Manager
 	client client.Client
@@ -77,6 +86,7 @@ var scheme = runtime.NewScheme()
 
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
 
 	_ = componentsapi.AddToScheme(scheme)
 	_ = configurationapi.AddToScheme(scheme)
@@ -85,15 +95,32 @@ func init() {
 	_ = subscriptionsapiV2alpha1.AddToScheme(scheme)
 }
 
-// NewOperator returns a new Dapr Operator.
-func NewOperator(opts Options) Operator {
+// NewOperator builds the core of a Dapr Operator (the controller-runtime manager and
+// client) and then initializes each of modules, in dependency order, against it. Feature
+// subsystems are opt-in: pass WatchdogModule, ServiceReconcilerModule,
+// ComponentInformerModule, CertProviderModule, APIServerModule, HealthzModule and/or
+// MetricsModule to enable them, or a custom OperatorModule to extend or replace one.
+func NewOperator(ctx context.Context, opts Options, modules ...OperatorModule) Operator {
 	conf, err := ctrl.GetConfig()
 	if err != nil {
 		log.Fatalf("Unable to get controller runtime configuration, err: %s", err)
 	}
+
+	// The manager's metrics bind address has to be decided before any module runs, so we
+	// peek the module list for MetricsModule rather than deciding it from within Init.
+	metricsBindAddress := "0"
+	for _, m := range modules {
+		if m.Name == ModuleMetrics {
+			metricsBindAddress = opts.MetricsBindAddress
+			if metricsBindAddress == "" {
+				metricsBindAddress = ":9090"
+			}
+		}
+	}
+
 	mgr, err := ctrl.NewManager(conf, ctrl.Options{
 		Scheme:             scheme,
-		MetricsBindAddress: "0",
+		MetricsBindAddress: metricsBindAddress,
 		LeaderElection:     opts.LeaderElection,
 		LeaderElectionID:   "operator.dapr.io",
 		Namespace:          opts.WatchNamespace,
@@ -101,56 +128,153 @@ func NewOperator(opts Options) Operator {
 	if err != nil {
 		log.Fatalf("Unable to start manager, err: %s", err)
 	}
-	mgrClient := mgr.GetClient()
 
-	if opts.WatchdogEnabled {
-		if !opts.LeaderElection {
-			log.Warn("Leadership election is forcibly enabled when the Dapr Watchdog is enabled")
-		}
-		wd := &DaprWatchdog{
-			client:            mgrClient,
-			interval:          opts.WatchdogInterval,
-			maxRestartsPerMin: opts.WatchdogMaxRestartsPerMin,
-		}
-		err = mgr.Add(wd)
-		if err != nil {
-			log.Fatalf("Unable to add watchdog controller, err: %s", err)
-		}
-	} else {
-		log.Infof("Dapr Watchdog is not enabled")
+	o := &operator{
+		mgr:        mgr,
+		client:     mgr.GetClient(),
+		configName: opts.Config,
 	}
 
-	if opts.ServiceReconcilerEnabled {
-		daprHandler := handlers.NewDaprHandlerWithOptions(mgr, &handlers.Options{ArgoRolloutServiceReconcilerEnabled: opts.ArgoRolloutServiceReconcilerEnabled})
-		err = daprHandler.Init()
-		if err != nil {
-			log.Fatalf("Unable to initialize handler, err: %s", err)
+	sorted, err := sortModules(modules)
+	if err != nil {
+		log.Fatalf("Invalid operator module configuration: %s", err)
+	}
+	for _, m := range sorted {
+		log.Infof("Initializing operator module %q", m.Name)
+		if err := m.Init(ctx, o); err != nil {
+			log.Fatalf("Unable to initialize operator module %q, err: %s", m.Name, err)
 		}
 	}
 
-	o := &operator{
-		mgr:           mgr,
-		client:        mgrClient,
-		configName:    opts.Config,
-		certChainPath: opts.CertChainPath,
+	return o
+}
+
+// forceResync re-lists every component from the manager's cache and re-publishes it to the
+// API server's sidecar notification channels, for use by the debug router's
+// ForceResync operation when a sidecar appears to have missed an update.
+func (o *operator) forceResync() {
+	var list componentsapi.ComponentList
+	if err := o.client.List(context.Background(), &list); err != nil {
+		log.Errorf("Forced resync failed to list components: %s", err)
+		return
 	}
-	o.apiServer = api.NewAPIServer(o.client)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	componentInformer, err := mgr.GetCache().GetInformer(ctx, &componentsapi.Component{})
-	cancel()
+	for i := range list.Items {
+		o.syncComponent(&list.Items[i])
+	}
+}
+
+// setupComponentInformer registers the components.dapr.io informer. It must only be
+// called once the CRD readiness gate has signaled that the CRD is installed and
+// Established, which setupInformers enforces.
+func (o *operator) setupComponentInformer(ctx context.Context) error {
+	componentInformer, err := o.mgr.GetCache().GetInformer(ctx, &componentsapi.Component{})
 	if err != nil {
-		log.Fatalf("Unable to get setup components informer, err: %s", err)
+		return err
 	}
 
 	componentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: o.syncComponent,
+		AddFunc: func(obj any) {
+			o.refreshComponentsWatched(ctx)
+			o.syncComponent(obj)
+		},
 		UpdateFunc: func(_, newObj any) {
 			o.syncComponent(newObj)
 		},
+		DeleteFunc: func(any) {
+			o.refreshComponentsWatched(ctx)
+		},
 	})
 
-	return o
+	return nil
+}
+
+// refreshComponentsWatched recomputes components_watched from a fresh List rather than
+// incrementally adjusting it from Add/Delete events: periodic informer resyncs redeliver
+// Adds for objects already counted, and Delete handlers can receive a
+// cache.DeletedFinalStateUnknown tombstone that fails a *componentsapi.Component type
+// assertion, so an incremental counter drifts upward forever. A List is cheap here since
+// it's served from the informer's local cache, not the API server.
+func (o *operator) refreshComponentsWatched(ctx context.Context) {
+	var list componentsapi.ComponentList
+	if err := o.client.List(ctx, &list); err != nil {
+		log.Errorf("Failed to list components to refresh components_watched: %s", err)
+		return
+	}
+
+	counts := make(map[string]int)
+	for i := range list.Items {
+		counts[list.Items[i].Namespace]++
+	}
+
+	componentsWatched.Reset()
+	for ns, count := range counts {
+		componentsWatched.WithLabelValues(ns).Set(float64(count))
+	}
+}
+
+// setupInformers waits for the CRD readiness gate before registering typed informers, so
+// the operator doesn't crash-loop on a fresh cluster where its CRDs and Deployment are
+// applied together. Required CRDs block startup; optional CRDs degrade gracefully by
+// skipping their informer when not installed. Optional CRDs are checked once, when the
+// gate opens: one installed later gets no informer until the operator restarts.
+func (o *operator) setupInformers(ctx context.Context) error {
+	select {
+	case <-o.crdWaiter.Ready():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := o.setupComponentInformer(ctx); err != nil {
+		return err
+	}
+
+	for _, opt := range o.crdWaiter.optional {
+		if !o.crdWaiter.isOptionalEstablished(opt.Name) {
+			log.Warnf("Skipping informer setup for %s: CRD not installed", opt.Name)
+			continue
+		}
+
+		for _, obj := range optionalInformerObjects(opt.Name) {
+			if err := o.setupOptionalInformer(ctx, opt.Name, obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// optionalInformerObjects returns the typed objects an informer should be started for
+// once the optional CRD named crdName is established. subscriptions.dapr.io covers both
+// served versions with a single CRDRequirement, so it maps to two distinct informers.
+func optionalInformerObjects(crdName string) []client.Object {
+	switch crdName {
+	case "resiliencies.dapr.io":
+		return []client.Object{&resiliencyapi.Resiliency{}}
+	case "subscriptions.dapr.io":
+		return []client.Object{&subscriptionsapiV1alpha1.Subscription{}, &subscriptionsapiV2alpha1.Subscription{}}
+	default:
+		return nil
+	}
+}
+
+// setupOptionalInformer starts the informer for obj and registers handlers that keep the
+// operator's local cache of it warm, so the corresponding API router (see
+// github.com/dapr/dapr/pkg/operator/api/routers) has data to serve once it's wired up.
+func (o *operator) setupOptionalInformer(ctx context.Context, crdName string, obj client.Object) error {
+	informer, err := o.mgr.GetCache().GetInformer(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { log.Debugf("Observed %s object added", crdName) },
+		UpdateFunc: func(_, _ any) { log.Debugf("Observed %s object updated", crdName) },
+		DeleteFunc: func(any) { log.Debugf("Observed %s object deleted", crdName) },
+	})
+
+	return nil
 }
 
 func (o *operator) prepareConfig() {
@@ -162,18 +286,50 @@ func (o *operator) prepareConfig() {
 	o.config.Credentials = credentials.NewTLSCredentials(o.certChainPath)
 }
 
+// watchSighup forwards SIGHUP to the certificate provider so an operator can force a
+// reload (e.g. `kubectl exec ... -- kill -HUP 1`) when a mounted secret volume doesn't
+// emit a filesystem event on update.
+func (o *operator) watchSighup(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(sigCh)
+				return
+			case <-sigCh:
+				log.Info("Received SIGHUP, forcing TLS certificate reload")
+				o.certProvider.Sighup()
+			}
+		}
+	}()
+}
+
 func (o *operator) syncComponent(obj any) {
 	c, ok := obj.(*componentsapi.Component)
-	if ok {
+	if !ok {
+		return
+	}
+	syncComponentTotal.WithLabelValues(c.Namespace).Inc()
+	if o.apiServer != nil {
 		log.Debugf("Observed component to be synced, %s/%s", c.Namespace, c.Name)
 		o.apiServer.OnComponentUpdated(c)
 	}
 }
 
-func (o *operator) loadCertChain(ctx context.Context) (certChain *credentials.CertChain) {
+// waitForCertProvider blocks until the initial TLS certificate can be loaded from disk,
+// then returns a CertProvider that keeps it fresh for the remaining lifetime of the
+// process. Unlike a one-shot load, the returned provider reloads the certificate
+// whenever cert-manager, the Dapr sentry, or a secret volume update it on disk, so a
+// rotated certificate never requires restarting the operator.
+func (o *operator) waitForCertProvider(ctx context.Context) (*CertProvider, error) {
 	log.Info("Getting TLS certificates")
 
 	watchCtx, watchCancel := context.WithTimeout(ctx, time.Minute)
+	defer watchCancel()
+
 	fsevent := make(chan struct{})
 	go func() {
 		log.Infof("Starting watch for certs on filesystem: %s", o.config.Credentials.Path())
@@ -183,26 +339,22 @@ func (o *operator) loadCertChain(ctx context.Context) (certChain *credentials.Ce
 			log.Fatalf("Error starting watch on filesystem: %s", err)
 		}
 		close(fsevent)
-		if watchCtx.Err() == context.DeadlineExceeded {
-			log.Fatal("Timeout while waiting to load TLS certificates")
-		}
 	}()
 
 	for {
-		chain, err := credentials.LoadFromDisk(o.config.Credentials.RootCertPath(), o.config.Credentials.CertPath(), o.config.Credentials.KeyPath())
+		cp, err := NewCertProvider(o.config.Credentials.RootCertPath(), o.config.Credentials.CertPath(), o.config.Credentials.KeyPath())
 		if err == nil {
 			log.Info("TLS certificates loaded successfully")
-			certChain = chain
-			break
+			return cp, nil
 		}
 		log.Infof("TLS certificate not found; waiting for disk changes. err=%v", err)
-		<-fsevent
-		log.Debug("Watcher found activity on filesystem")
+		select {
+		case <-fsevent:
+			log.Debug("Watcher found activity on filesystem")
+		case <-watchCtx.Done():
+			return nil, errors.New("timeout while waiting to load TLS certificates")
+		}
 	}
-
-	watchCancel()
-
-	return certChain
 }
 
 func (o *operator) Run(ctx context.Context) {
@@ -218,26 +370,52 @@ func (o *operator) Run(ctx context.Context) {
 	if !o.mgr.GetCache().WaitForCacheSync(ctx) {
 		log.Fatalf("Failed to wait for cache sync")
 	}
-	o.prepareConfig()
 
-	// load certs from disk
-	certChain := o.loadCertChain(ctx)
+	if o.crdWaiter != nil {
+		log.Info("Waiting for required CRDs to be installed")
+		if err := o.setupInformers(ctx); err != nil {
+			log.Fatalf("Failed waiting for CRDs and setting up informers, err: %s", err)
+		}
+	}
 
-	// start healthz server
-	healthzServer := health.NewServer(log)
-	go func() {
-		// blocking call
-		err := healthzServer.Run(ctx, healthzPort)
+	if o.certProviderEnabled {
+		o.prepareConfig()
+
+		certProvider, err := o.waitForCertProvider(ctx)
 		if err != nil {
-			log.Fatalf("Failed to start healthz server: %s", err)
+			log.Fatalf("Failed to load TLS certificates: %s", err)
 		}
-	}()
+		o.certProvider = certProvider
+		go func() {
+			if err := o.certProvider.Run(ctx); err != nil {
+				log.Errorf("Certificate provider stopped unexpectedly: %s", err)
+			}
+		}()
+		o.watchSighup(ctx)
+	}
 
-	// blocking call
-	o.apiServer.Run(ctx, certChain, func() {
-		healthzServer.Ready()
-		log.Infof("Dapr Operator started")
-	})
+	var healthzServer *health.Server
+	if o.healthzPort != 0 {
+		healthzServer = health.NewServer(log)
+		go func() {
+			// blocking call
+			if err := healthzServer.Run(ctx, o.healthzPort); err != nil {
+				log.Fatalf("Failed to start healthz server: %s", err)
+			}
+		}()
+	}
+
+	if o.apiServer != nil {
+		// blocking call
+		o.apiServer.Run(ctx, o.certProvider, func() {
+			if healthzServer != nil {
+				healthzServer.Ready()
+			}
+			log.Infof("Dapr Operator started")
+		})
+	} else {
+		<-ctx.Done()
+	}
 
 	log.Infof("Dapr Operator is shutting down")
 }